@@ -1,298 +1,633 @@
-package main
-
-import (
-	"bufio"
-	"container/list"
-	"fmt"
-	"io"
-	"os"
-	"sort"
-	"strconv"
-	"strings"
-)
-
-// SimulationResult agrupa os resultados de uma simulação de substituição de páginas.
-type SimulationResult struct {
-	pageFaults int            // Total de faltas de página
-	loadCounts map[string]int // Quantas vezes cada página foi carregada na memória
-}
-
-// parseMemorySize converte uma string de tamanho de memória (ex: "8MB", "16KB") para o valor em bytes.
-//
-// :param sizeStr: String contendo o tamanho da memória (ex: "8MB")
-// :return: Valor convertido em bytes e um erro, se houver
-func parseMemorySize(sizeStr string) (int64, error) {
-	sizeStr = strings.ToUpper(strings.TrimSpace(sizeStr))
-	var multiplier int64 = 1
-
-	if strings.HasSuffix(sizeStr, "KB") {
-		multiplier = 1024
-		sizeStr = strings.TrimSuffix(sizeStr, "KB")
-	} else if strings.HasSuffix(sizeStr, "MB") {
-		multiplier = 1024 * 1024
-		sizeStr = strings.TrimSuffix(sizeStr, "MB")
-	} else if strings.HasSuffix(sizeStr, "GB") {
-		multiplier = 1024 * 1024 * 1024
-		sizeStr = strings.TrimSuffix(sizeStr, "GB")
-	}
-
-	value, err := strconv.ParseInt(sizeStr, 10, 64)
-	if err != nil {
-		return 0, err
-	}
-	return value * multiplier, nil
-}
-
-// fifoAlgorithm implementa o algoritmo de substituição de páginas FIFO.
-//
-// Utiliza uma lista duplamente encadeada (`list.List`) para armazenar a ordem de chegada
-// das páginas e um mapa (`map[string]struct{}`) para checagem rápida de presença.
-//
-// :param pageReferences: Sequência de referências de página
-// :param numFrames: Número de quadros de memória disponíveis
-// :param didacticMode: Se true, imprime passo a passo
-// :return: Resultado da simulação (faltas de página e carregamentos)
-func fifoAlgorithm(pageReferences []string, numFrames int, didacticMode bool) SimulationResult {
-	pageInMmemorySet := make(map[string]struct{}) // Set de páginas atualmente na memória
-	memoryFrames := list.New()                    // Lista FIFO das páginas
-	result := SimulationResult{pageFaults: 0, loadCounts: make(map[string]int)}
-
-	for i, page := range pageReferences {
-		if didacticMode {
-			fmt.Printf("\n[FIFO - Passo %d] Acessando página: %s\n", i+1, page)
-		}
-
-		if _, found := pageInMmemorySet[page]; !found {
-			result.pageFaults++
-			result.loadCounts[page]++
-
-			var evictedPage string
-			if memoryFrames.Len() == numFrames {
-				oldestPageElement := memoryFrames.Front()
-				evictedPage = oldestPageElement.Value.(string)
-				delete(pageInMmemorySet, evictedPage)
-				memoryFrames.Remove(oldestPageElement)
-			}
-
-			memoryFrames.PushBack(page)
-			pageInMmemorySet[page] = struct{}{}
-
-			if didacticMode {
-				fmt.Printf("  -> FALTA DE PáGINA (FAULT)!\n")
-				if evictedPage != "" {
-					fmt.Printf("     Página removida: %s\n", evictedPage)
-				}
-				fmt.Printf("     Página inserida: %s\n", page)
-			}
-		} else if didacticMode {
-			fmt.Printf("  -> Página encontrada (HIT)!\n")
-		}
-
-		if didacticMode {
-			var framesState []string
-			for e := memoryFrames.Front(); e != nil; e = e.Next() {
-				framesState = append(framesState, e.Value.(string))
-			}
-			fmt.Printf("  Estado da memoria: %v\n", framesState)
-		}
-	}
-	return result
-}
-
-// optimalAlgorithmOptimized implementa o algoritmo ótimo de substituição de páginas.
-//
-// Utiliza pré-processamento das posições futuras de uso para decidir qual página remover.
-// Remove aquela que será usada mais tarde ou nunca mais usada.
-//
-// :param pageReferences: Sequência de referências de página
-// :param numFrames: Número de quadros de memória disponíveis
-// :param pagePositions: Mapa com posições futuras de uso de cada página
-// :param didacticMode: Se true, imprime passo a passo
-// :return: Resultado da simulação (faltas de página e carregamentos)
-func optimalAlgorithmOptimized(pageReferences []string, numFrames int, pagePositions map[string][]int, didacticMode bool) SimulationResult {
-	pageInMmemorySet := make(map[string]struct{}) // Set de páginas em memória
-	memoryFrames := make([]string, 0, numFrames)  // Lista de páginas na memória
-	result := SimulationResult{pageFaults: 0, loadCounts: make(map[string]int)}
-	nextUseCursor := make(map[string]int) // Cursor de leitura para cada página
-
-	for i, page := range pageReferences {
-		if didacticMode {
-			fmt.Printf("\n[Ótimo - Passo %d] Acessando página: %s\n", i+1, page)
-		}
-
-		if _, found := pageInMmemorySet[page]; !found {
-			result.pageFaults++
-			result.loadCounts[page]++
-
-			var evictedPage string
-			if len(memoryFrames) < numFrames {
-				memoryFrames = append(memoryFrames, page)
-				pageInMmemorySet[page] = struct{}{}
-			} else {
-				farthest := -1
-				victimIndex := -1
-
-				for frameIdx, framePage := range memoryFrames {
-					positions := pagePositions[framePage]
-					cursor := nextUseCursor[framePage]
-
-					nextPos := -1
-					for cursor < len(positions) && positions[cursor] <= i {
-						cursor++
-					}
-					nextUseCursor[framePage] = cursor
-					if cursor < len(positions) {
-						nextPos = positions[cursor]
-					}
-
-					if nextPos == -1 {
-						victimIndex = frameIdx
-						break
-					}
-					if nextPos > farthest {
-						farthest = nextPos
-						victimIndex = frameIdx
-					}
-				}
-
-				evictedPage = memoryFrames[victimIndex]
-				delete(pageInMmemorySet, evictedPage)
-				memoryFrames[victimIndex] = page
-				pageInMmemorySet[page] = struct{}{}
-			}
-
-			if didacticMode {
-				fmt.Printf("  -> FALTA DE PáGINA (FAULT)!\n")
-				if evictedPage != "" {
-					fmt.Printf("     Página removida: %s\n", evictedPage)
-				}
-				fmt.Printf("     Página inserida: %s\n", page)
-			}
-		} else if didacticMode {
-			fmt.Printf("  -> Página encontrada (HIT)!\n")
-		}
-
-		if didacticMode {
-			framesState := make([]string, len(memoryFrames))
-			copy(framesState, memoryFrames)
-			sort.Strings(framesState)
-			fmt.Printf("  Estado da memoria: %v\n", framesState)
-		}
-	}
-	return result
-}
-
-// main coordena a execução do simulador. Lê o arquivo de entrada, calcula os parâmetros de memória,
-// executa os algoritmos FIFO e Ótimo, imprime os resultados e pergunta se o usuário quer ver os detalhes.
-func main() {
-	if len(os.Args) < 3 {
-		fmt.Fprintf(os.Stderr, "Uso: %s [--didatico] <arquivo_de_entrada> <tamanho_memoria>\n", os.Args[0])
-		os.Exit(1)
-	}
-
-	didacticMode := false
-	args := os.Args[1:]
-	if args[0] == "--didatico" {
-		didacticMode = true
-		args = args[1:]
-	}
-
-	if len(args) != 2 {
-		fmt.Fprintf(os.Stderr, "Argumentos invalidos. Uso: %s [--didatico] <arquivo_de_entrada> <tamanho_memoria>\n", os.Args[0])
-		os.Exit(1)
-	}
-
-	memorySizeStr := args[1]
-
-	const pageSizeBytes = 4 * 1024 // Cada página tem 4KB
-	physicalMemoryBytes, _ := parseMemorySize(memorySizeStr)
-
-	if physicalMemoryBytes < pageSizeBytes {
-		fmt.Fprintf(os.Stderr, "Tamanho de memória deve ser maior que 4KB. Uso: %s [--didatico] <arquivo_de_entrada> <tamanho_memoria>\n", os.Args[0])
-		os.Exit(1)
-	}
-
-	filePath := args[0]
-	
-	// Leitura do arquivo de referências
-	file, err := os.Open(filePath)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Erro: O arquivo '%s' nao foi encontrado.\n", filePath)
-		os.Exit(1)
-	}
-	defer file.Close()
-
-	pageReferences := make([]string, 0)
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		pageReferences = append(pageReferences, scanner.Text())
-	}
-
-	// Pré-processamento das posições das páginas
-	fmt.Println("Iniciando pre-processamento do arquivo de referencias...")
-	pagePositions := make(map[string][]int)
-	for i, page := range pageReferences {
-		pagePositions[page] = append(pagePositions[page], i)
-	}
-	fmt.Println("Pre-processamento concluido.")
-
-	numFrames := int(physicalMemoryBytes / pageSizeBytes)
-
-	// Contar páginas distintas para calcular tamanho da tabela
-	distinctPagesSet := make(map[string]struct{})
-	for _, page := range pageReferences {
-		distinctPagesSet[page] = struct{}{}
-	}
-	distinctPagesCount := len(distinctPagesSet)
-
-	const sizeOfPTE = 4
-	tableSize := distinctPagesCount * sizeOfPTE
-
-	var optimalResult, fifoResult SimulationResult
-
-	if didacticMode && len(pageReferences) > 1000 {
-		fmt.Println("AVISO: O modo didatico com muitas referencias pode gerar saida muito longa!")
-	}
-
-	optimalResult = optimalAlgorithmOptimized(pageReferences, numFrames, pagePositions, didacticMode)
-	fifoResult = fifoAlgorithm(pageReferences, numFrames, didacticMode)
-
-	// Impressão de estatísticas
-	fmt.Println("\n--- RESULTADO DA SIMULACAO ---")
-	fmt.Printf("A memória física comporta %d páginas.\n", numFrames)
-	fmt.Printf("Ha %d páginas distintas no arquivo.\n", distinctPagesCount)
-	fmt.Printf("Tamanho estimado da Tabela de Páginas (1 nivel): %d bytes (%d entradas * %d bytes/entrada)\n", tableSize, distinctPagesCount, sizeOfPTE)
-
-	fmt.Printf("Com o algoritmo Ótimo ocorrem %d faltas de página.\n", optimalResult.pageFaults)
-	fmt.Printf("Com o algoritmo FIFO ocorrem %d faltas de página,\n", fifoResult.pageFaults)
-
-	efficiency := 100.0
-	if fifoResult.pageFaults > 0 {
-		efficiency = (float64(optimalResult.pageFaults) / float64(fifoResult.pageFaults)) * 100.0
-	}
-	fmt.Printf("atingindo %.2f%% do desempenho do Ótimo.\n", efficiency)
-
-	// Pergunta se deseja imprimir estatísticas por página
-	fmt.Print("Deseja listar o numero de carregamentos (s/n)? ")
-	var choice string
-	_, err = fmt.Scanln(&choice)
-	if err != nil && err != io.EOF {
-		choice = "n"
-	}
-
-	if strings.ToLower(choice) == "s" {
-		distinctPages := make([]string, 0, len(distinctPagesSet))
-		for page := range distinctPagesSet {
-			distinctPages = append(distinctPages, page)
-		}
-		sort.Strings(distinctPages)
-
-		fmt.Println("\nPágina\tÓtimo\tFIFO")
-		fmt.Println("------\t-----\t----")
-		for _, page := range distinctPages {
-			optCount := optimalResult.loadCounts[page]
-			fifoCount := fifoResult.loadCounts[page]
-			fmt.Printf("%s\t%d\t%d\n", page, optCount, fifoCount)
-		}
-	}
-}
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chrisesf/Virtual-Memory-Simulator-GO/pagerepl"
+)
+
+// didacticRecorder acumula a saída do modo didático em blocos, um por passo de simulação, para
+// que depois possam ser paginados sem nunca partir um passo ao meio.
+type didacticRecorder struct {
+	blocks  []string
+	current strings.Builder
+}
+
+// newDidacticRecorder cria um didacticRecorder vazio.
+func newDidacticRecorder() *didacticRecorder {
+	return &didacticRecorder{}
+}
+
+// startStep encerra o bloco do passo anterior, caso exista, e inicia um novo.
+func (r *didacticRecorder) startStep() {
+	r.flushCurrent()
+}
+
+// Printf grava uma linha no bloco do passo corrente.
+func (r *didacticRecorder) Printf(format string, args ...interface{}) {
+	fmt.Fprintf(&r.current, format, args...)
+}
+
+// finish encerra o último bloco em aberto, se houver.
+func (r *didacticRecorder) finish() {
+	r.flushCurrent()
+}
+
+func (r *didacticRecorder) flushCurrent() {
+	if r.current.Len() > 0 {
+		r.blocks = append(r.blocks, r.current.String())
+		r.current.Reset()
+	}
+}
+
+// writeDidacticEvent formata um pagerepl.Event no mesmo estilo passo a passo do modo didático
+// e o grava como um único bloco no recorder.
+//
+// :param recorder: Recorder que acumula a saída didática
+// :param event: Evento emitido pelo Simulator para um acesso de um algoritmo
+func writeDidacticEvent(recorder *didacticRecorder, event pagerepl.Event) {
+	recorder.startStep()
+	recorder.Printf("\n[%s - Passo %d] Acessando página: %s\n", event.Algorithm, event.Step, event.Page)
+	if event.Fault {
+		recorder.Printf("  -> FALTA DE PáGINA (FAULT)!\n")
+		if event.Evicted != "" {
+			recorder.Printf("     Página removida: %s\n", event.Evicted)
+		}
+		recorder.Printf("     Página inserida: %s\n", event.Page)
+	} else {
+		recorder.Printf("  -> Página encontrada (HIT)!\n")
+	}
+	recorder.Printf("  Estado da memoria: %v\n", event.Frames)
+}
+
+// paginateDidacticBlocks agrupa os blocos de passo em páginas de no máximo pageLines linhas,
+// sem nunca dividir um único bloco entre duas páginas.
+//
+// :param blocks: Blocos de passo, um por acesso simulado
+// :param pageLines: Número máximo de linhas por página
+// :return: Páginas já concatenadas, prontas para exibição ou gravação
+func paginateDidacticBlocks(blocks []string, pageLines int) []string {
+	var pages []string
+	var current strings.Builder
+	currentLines := 0
+
+	for _, block := range blocks {
+		blockLines := strings.Count(block, "\n")
+		if currentLines > 0 && currentLines+blockLines > pageLines {
+			pages = append(pages, current.String())
+			current.Reset()
+			currentLines = 0
+		}
+		current.WriteString(block)
+		currentLines += blockLines
+	}
+	if current.Len() > 0 {
+		pages = append(pages, current.String())
+	}
+	return pages
+}
+
+// showDidacticPagesInteractive exibe as páginas uma a uma no terminal, aguardando (n)ext,
+// (p)rev ou (q)uit entre elas.
+//
+// :param pages: Páginas já formadas pela paginação
+func showDidacticPagesInteractive(pages []string) {
+	reader := bufio.NewReader(os.Stdin)
+	index := 0
+	for index < len(pages) {
+		fmt.Print(pages[index])
+		fmt.Printf("-- pagina %d/%d (n)ext (p)rev (q)uit --", index+1, len(pages))
+
+		line, err := reader.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return
+		}
+		switch strings.ToLower(strings.TrimSpace(line)) {
+		case "p":
+			if index > 0 {
+				index--
+			}
+		case "q":
+			return
+		default:
+			index++
+		}
+	}
+}
+
+// writeDidacticPages grava cada página em um arquivo separado dentro de outDir, nomeados
+// page-0001.txt, page-0002.txt, etc., para consulta posterior sem interação.
+//
+// :param pages: Páginas já formadas pela paginação
+// :param outDir: Diretório de destino (criado se não existir)
+// :return: Erro, se a gravação falhar
+func writeDidacticPages(pages []string, outDir string) error {
+	if err := os.MkdirAll(outDir, 0o755); err != nil {
+		return err
+	}
+	for i, page := range pages {
+		pageFile := filepath.Join(outDir, fmt.Sprintf("page-%04d.txt", i+1))
+		if err := os.WriteFile(pageFile, []byte(page), 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renderDidacticOutput quebra os blocos gravados pelo recorder em páginas e as exibe
+// interativamente ou, se outDir não for vazio, as grava em disco.
+//
+// :param recorder: Recorder preenchido pelas simulações em modo didático
+// :param pageLines: Número máximo de linhas por página
+// :param outDir: Se não vazio, diretório onde gravar as páginas em vez de exibi-las
+// :return: Erro, se a gravação em disco falhar
+func renderDidacticOutput(recorder *didacticRecorder, pageLines int, outDir string) error {
+	pages := paginateDidacticBlocks(recorder.blocks, pageLines)
+	if outDir != "" {
+		return writeDidacticPages(pages, outDir)
+	}
+	showDidacticPagesInteractive(pages)
+	return nil
+}
+
+// parseMemorySize converte uma string de tamanho de memória (ex: "8MB", "16KB") para o valor em bytes.
+//
+// :param sizeStr: String contendo o tamanho da memória (ex: "8MB")
+// :return: Valor convertido em bytes e um erro, se houver
+func parseMemorySize(sizeStr string) (int64, error) {
+	sizeStr = strings.ToUpper(strings.TrimSpace(sizeStr))
+	var multiplier int64 = 1
+
+	if strings.HasSuffix(sizeStr, "KB") {
+		multiplier = 1024
+		sizeStr = strings.TrimSuffix(sizeStr, "KB")
+	} else if strings.HasSuffix(sizeStr, "MB") {
+		multiplier = 1024 * 1024
+		sizeStr = strings.TrimSuffix(sizeStr, "MB")
+	} else if strings.HasSuffix(sizeStr, "GB") {
+		multiplier = 1024 * 1024 * 1024
+		sizeStr = strings.TrimSuffix(sizeStr, "GB")
+	}
+
+	value, err := strconv.ParseInt(sizeStr, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return value * multiplier, nil
+}
+
+// estimatePageTable calcula o tamanho total, em bytes, de uma tabela de páginas hierárquica
+// com o número de níveis informado.
+//
+// Os números das páginas virtuais acessadas são usados para descobrir, nível a nível, quais nós
+// internos (diretórios) realmente precisam existir: cada número de página é dividido em
+// `levels` faixas de bits iguais, cujo tamanho é determinado pelo fan-out de uma tabela
+// (`pageSizeBytes / pteSize` entradas por página de tabela). Percorrendo uma única vez o
+// conjunto de páginas, constrói-se uma trie esparsa dos nós populados em cada nível; o
+// tamanho final é a soma, em cada nível, de `nos_populados * fanout * pteSize`.
+//
+// :param virtualPages: Números de página virtual distintos observados no trace
+// :param pageSizeBytes: Tamanho da página (cada nível ocupa uma página inteira de tabela)
+// :param pteSize: Tamanho de uma entrada de tabela de páginas, em bytes
+// :param levels: Número de níveis da tabela hierárquica (2 a 4)
+// :return: Tamanho estimado da tabela de páginas hierárquica, em bytes
+func estimatePageTable(virtualPages []int, pageSizeBytes, pteSize, levels int) int64 {
+	fanout := pageSizeBytes / pteSize
+	if fanout < 2 {
+		fanout = 2
+	}
+
+	bitsPerLevel := 0
+	for (1 << bitsPerLevel) < fanout {
+		bitsPerLevel++
+	}
+	mask := (1 << bitsPerLevel) - 1
+
+	populatedNodes := make([]map[string]struct{}, levels)
+	for level := range populatedNodes {
+		populatedNodes[level] = make(map[string]struct{})
+	}
+
+	for _, page := range virtualPages {
+		prefix := ""
+		for level := 0; level < levels; level++ {
+			populatedNodes[level][prefix] = struct{}{}
+			shift := bitsPerLevel * (levels - 1 - level)
+			index := (page >> shift) & mask
+			prefix += fmt.Sprintf("/%d", index)
+		}
+	}
+
+	var total int64
+	for level := 0; level < levels; level++ {
+		total += int64(len(populatedNodes[level])) * int64(fanout) * int64(pteSize)
+	}
+	return total
+}
+
+// flatAddressSpaceTableSize calcula o tamanho, em bytes, de uma tabela de páginas de nível único
+// que cobrisse a totalidade do espaço de endereçamento virtual representável com o mesmo fan-out
+// usado por estimatePageTable (fanout elevado a levels entradas), e não apenas as páginas
+// distintas observadas no trace. É a base de comparação correta para medir a economia de espaço
+// de uma tabela hierárquica: comparar contra a tabela das páginas observadas sempre mostraria a
+// tabela hierárquica como maior, já que ela aloca uma página inteira de diretório por nível mesmo
+// para poucas entradas.
+//
+// :param pageSizeBytes: Tamanho da página (determina o fan-out de uma tabela)
+// :param pteSize: Tamanho de uma entrada de tabela de páginas, em bytes
+// :param levels: Número de níveis da tabela hierárquica
+// :return: Tamanho, em bytes, de uma tabela de nível único cobrindo todo o espaço de endereçamento
+func flatAddressSpaceTableSize(pageSizeBytes, pteSize, levels int) int64 {
+	fanout := pageSizeBytes / pteSize
+	if fanout < 2 {
+		fanout = 2
+	}
+
+	total := int64(1)
+	for i := 0; i < levels; i++ {
+		total *= int64(fanout)
+	}
+	return total * int64(pteSize)
+}
+
+// generateReferenceString gera uma sequência de endereços seguindo o modelo clássico de
+// localidade mista: a cada posição, sorteia entre (1) avançar sequencialmente a partir da
+// instrução corrente, (2) acessar um endereço aleatório na região já visitada (localidade de
+// frente, em [0, m+1]) ou (3) saltar para uma nova localidade aleatória em [m+2, N-1] e, a partir
+// dela, emitir também um endereço aleatório na região ainda não visitada (localidade de trás),
+// retomando a execução sequencial a partir do novo ponto.
+//
+// :param length: Número total de referências a gerar
+// :param addressSpaceSize: Tamanho do espaço de endereçamento (N)
+// :param pctSequential: Percentual de referências sequenciais
+// :param pctFrontLocality: Percentual de referências aleatórias na região de frente
+// :param pctJump: Percentual de saltos para uma nova localidade
+// :param seed: Semente do gerador pseudoaleatório, para reprodutibilidade
+// :return: Sequência de endereços gerada
+func generateReferenceString(length int, addressSpaceSize int, pctSequential, pctFrontLocality, pctJump float64, seed int64) []int {
+	rng := rand.New(rand.NewSource(seed))
+	addresses := make([]int, 0, length)
+	m := rng.Intn(addressSpaceSize)
+
+	for len(addresses) < length {
+		roll := rng.Float64() * 100
+
+		switch {
+		case roll < pctSequential:
+			if m < addressSpaceSize-1 {
+				m++
+			}
+			addresses = append(addresses, m)
+		case roll < pctSequential+pctFrontLocality:
+			upper := m + 2
+			if upper > addressSpaceSize {
+				upper = addressSpaceSize
+			}
+			addresses = append(addresses, rng.Intn(upper))
+		case roll < pctSequential+pctFrontLocality+pctJump:
+			fallthrough
+		default:
+			lo, hi := m+2, addressSpaceSize-1
+			if lo > hi {
+				lo, hi = 0, addressSpaceSize-1
+			}
+			newLocality := lo + rng.Intn(hi-lo+1)
+			addresses = append(addresses, newLocality)
+			m = newLocality
+
+			if len(addresses) < length {
+				backLo, backHi := m, addressSpaceSize-1
+				addresses = append(addresses, backLo+rng.Intn(backHi-backLo+1))
+			}
+		}
+	}
+	return addresses[:length]
+}
+
+// parseMix interpreta a flag --mix no formato "sequencial,frente,salto" e valida que os três
+// percentuais somem 100.
+//
+// :param mix: String no formato "seq,front,jump"
+// :return: Os três percentuais e um erro, se a string for inválida
+func parseMix(mix string) (pctSequential, pctFrontLocality, pctJump float64, err error) {
+	parts := strings.Split(mix, ",")
+	if len(parts) != 3 {
+		return 0, 0, 0, fmt.Errorf("esperados 3 valores separados por vírgula, recebido %q", mix)
+	}
+
+	values := make([]float64, 3)
+	for i, part := range parts {
+		value, convErr := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if convErr != nil {
+			return 0, 0, 0, fmt.Errorf("valor invalido %q: %w", part, convErr)
+		}
+		values[i] = value
+	}
+
+	if soma := values[0] + values[1] + values[2]; math.Abs(soma-100) > 0.001 {
+		return 0, 0, 0, fmt.Errorf("os percentuais devem somar 100, somaram %.2f", soma)
+	}
+	return values[0], values[1], values[2], nil
+}
+
+// runGenerate implementa o subcomando `gen`, que escreve em disco um arquivo de referências
+// sintético pronto para ser consumido pelo simulador.
+//
+// :param args: Argumentos após o subcomando "gen"
+func runGenerate(args []string) {
+	fs := flag.NewFlagSet("gen", flag.ExitOnError)
+	length := fs.Int("length", 10000, "numero total de referencias a gerar")
+	addressSpace := fs.Int("addr-space", 1<<20, "tamanho do espaco de enderecamento N")
+	pageSize := fs.Int("page-size", 4*1024, "tamanho da pagina em bytes")
+	mix := fs.String("mix", "50,25,25", "percentuais sequencial,frente,salto (devem somar 100)")
+	seed := fs.Int64("seed", -1, "semente do gerador aleatorio; se omitida, usa o relogio do sistema")
+	out := fs.String("out", "", "arquivo de saida, com um numero de pagina por linha (obrigatorio)")
+	fs.Parse(args)
+
+	if *out == "" {
+		fmt.Fprintln(os.Stderr, "Uso: gen --out <arquivo> [--length N] [--addr-space N] [--page-size N] [--mix seq,frente,salto] [--seed N]")
+		os.Exit(1)
+	}
+
+	pctSequential, pctFrontLocality, pctJump, err := parseMix(*mix)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Erro na flag --mix: %v\n", err)
+		os.Exit(1)
+	}
+
+	seedValue := *seed
+	if seedValue < 0 {
+		seedValue = time.Now().UnixNano()
+	}
+
+	addresses := generateReferenceString(*length, *addressSpace, pctSequential, pctFrontLocality, pctJump, seedValue)
+
+	file, err := os.Create(*out)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Erro: nao foi possivel criar '%s': %v\n", *out, err)
+		os.Exit(1)
+	}
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+	for _, addr := range addresses {
+		fmt.Fprintln(writer, addr / *pageSize)
+	}
+	if err := writer.Flush(); err != nil {
+		fmt.Fprintf(os.Stderr, "Erro ao escrever em '%s': %v\n", *out, err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Geradas %d referencias (espaco de enderecos %d, pagina de %d bytes) em '%s'.\n", len(addresses), *addressSpace, *pageSize, *out)
+}
+
+// main coordena a execução do simulador. Lê o arquivo de entrada, calcula os parâmetros de memória,
+// conduz os algoritmos FIFO, Ótimo, LRU, LFU e NUR através de um pagerepl.Simulator, imprime os
+// resultados e pergunta se o usuário quer ver os detalhes.
+func main() {
+	if len(os.Args) > 1 && os.Args[1] == "gen" {
+		runGenerate(os.Args[2:])
+		return
+	}
+
+	const usage = "Uso: %s [--didatico] [--levels=N] [--page-lines=N] [--page-out=dir] <arquivo_de_entrada> <tamanho_memoria>\n"
+
+	if len(os.Args) < 3 {
+		fmt.Fprintf(os.Stderr, usage, os.Args[0])
+		os.Exit(1)
+	}
+
+	didacticMode := false
+	levels := 1
+	pageLines := 40
+	pageOutDir := ""
+	args := os.Args[1:]
+argsLoop:
+	for len(args) > 0 {
+		switch {
+		case args[0] == "--didatico":
+			didacticMode = true
+			args = args[1:]
+		case strings.HasPrefix(args[0], "--levels="):
+			levelsStr := strings.TrimPrefix(args[0], "--levels=")
+			parsedLevels, convErr := strconv.Atoi(levelsStr)
+			if convErr != nil || parsedLevels < 1 || parsedLevels > 4 {
+				fmt.Fprintf(os.Stderr, "Valor invalido para --levels: %q (use um inteiro de 1 a 4)\n", levelsStr)
+				os.Exit(1)
+			}
+			levels = parsedLevels
+			args = args[1:]
+		case strings.HasPrefix(args[0], "--page-lines="):
+			pageLinesStr := strings.TrimPrefix(args[0], "--page-lines=")
+			parsedPageLines, convErr := strconv.Atoi(pageLinesStr)
+			if convErr != nil || parsedPageLines < 1 {
+				fmt.Fprintf(os.Stderr, "Valor invalido para --page-lines: %q (use um inteiro positivo)\n", pageLinesStr)
+				os.Exit(1)
+			}
+			pageLines = parsedPageLines
+			args = args[1:]
+		case strings.HasPrefix(args[0], "--page-out="):
+			pageOutDir = strings.TrimPrefix(args[0], "--page-out=")
+			args = args[1:]
+		default:
+			break argsLoop
+		}
+	}
+
+	if len(args) != 2 {
+		fmt.Fprintf(os.Stderr, "Argumentos invalidos. "+usage, os.Args[0])
+		os.Exit(1)
+	}
+
+	memorySizeStr := args[1]
+
+	const pageSizeBytes = 4 * 1024 // Cada página tem 4KB
+	physicalMemoryBytes, _ := parseMemorySize(memorySizeStr)
+
+	if physicalMemoryBytes < pageSizeBytes {
+		fmt.Fprintf(os.Stderr, "Tamanho de memória deve ser maior que 4KB. "+usage, os.Args[0])
+		os.Exit(1)
+	}
+
+	filePath := args[0]
+
+	// Leitura do arquivo de referências
+	file, err := os.Open(filePath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Erro: O arquivo '%s' nao foi encontrado.\n", filePath)
+		os.Exit(1)
+	}
+	defer file.Close()
+
+	pageReferences := make([]string, 0)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		pageReferences = append(pageReferences, scanner.Text())
+	}
+
+	// Pré-processamento das posições das páginas
+	fmt.Println("Iniciando pre-processamento do arquivo de referencias...")
+	hints := pagerepl.Hints{PagePositions: pagerepl.BuildPagePositions(pageReferences)}
+	fmt.Println("Pre-processamento concluido.")
+
+	numFrames := int(physicalMemoryBytes / pageSizeBytes)
+
+	// Contar páginas distintas para calcular tamanho da tabela
+	distinctPagesSet := make(map[string]struct{})
+	for _, page := range pageReferences {
+		distinctPagesSet[page] = struct{}{}
+	}
+	distinctPagesCount := len(distinctPagesSet)
+
+	const sizeOfPTE = 4
+	tableSize := distinctPagesCount * sizeOfPTE
+
+	// Só tenta a estimativa hierárquica se os rótulos de página forem números de página válidos
+	var virtualPages []int
+	multiLevelNumericPages := true
+	if levels > 1 {
+		virtualPages = make([]int, 0, distinctPagesCount)
+		for page := range distinctPagesSet {
+			value, convErr := strconv.Atoi(page)
+			if convErr != nil {
+				multiLevelNumericPages = false
+				break
+			}
+			virtualPages = append(virtualPages, value)
+		}
+	}
+
+	var recorder *didacticRecorder
+	if didacticMode {
+		recorder = newDidacticRecorder()
+		if len(pageReferences) > 1000 {
+			fmt.Printf("AVISO: O modo didatico com muitas referencias gera uma saida longa, paginada em blocos de %d linhas.\n", pageLines)
+		}
+	}
+
+	simulator := pagerepl.NewSimulator(numFrames,
+		pagerepl.NewOptimalAlgorithm(),
+		pagerepl.NewFIFOAlgorithm(),
+		pagerepl.NewLRUAlgorithm(),
+		pagerepl.NewLFUAlgorithm(),
+		pagerepl.NewNURAlgorithm(),
+	)
+
+	events, resultsCh, err := simulator.Run(pagerepl.SlicePageSource(pageReferences), hints, didacticMode)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Erro ao simular: %v\n", err)
+		os.Exit(1)
+	}
+
+	for event := range events {
+		if recorder != nil {
+			writeDidacticEvent(recorder, event)
+		}
+	}
+	resultsByAlgorithm := <-resultsCh
+
+	if recorder != nil {
+		recorder.finish()
+		if err := renderDidacticOutput(recorder, pageLines, pageOutDir); err != nil {
+			fmt.Fprintf(os.Stderr, "Erro ao renderizar a saida didatica: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	optimalResult := resultsByAlgorithm["Ótimo"]
+	fifoResult := resultsByAlgorithm["FIFO"]
+	lruResult := resultsByAlgorithm["LRU"]
+	lfuResult := resultsByAlgorithm["LFU"]
+	nurResult := resultsByAlgorithm["NUR"]
+
+	// Ordem de exibição dos algoritmos nas tabelas de resumo
+	type algorithmSummary struct {
+		name   string
+		result pagerepl.SimulationResult
+	}
+	summaries := []algorithmSummary{
+		{"Ótimo", optimalResult},
+		{"FIFO", fifoResult},
+		{"LRU", lruResult},
+		{"LFU", lfuResult},
+		{"NUR", nurResult},
+	}
+
+	// Impressão de estatísticas
+	fmt.Println("\n--- RESULTADO DA SIMULACAO ---")
+	fmt.Printf("A memória física comporta %d páginas.\n", numFrames)
+	fmt.Printf("Ha %d páginas distintas no arquivo.\n", distinctPagesCount)
+	fmt.Printf("Tamanho estimado da Tabela de Páginas (1 nivel): %d bytes (%d entradas * %d bytes/entrada)\n", tableSize, distinctPagesCount, sizeOfPTE)
+
+	if levels > 1 {
+		if !multiLevelNumericPages {
+			fmt.Printf("Nao foi possivel estimar a tabela hierarquica de %d niveis: os rotulos de pagina do arquivo nao sao numericos.\n", levels)
+		} else {
+			multiLevelTableSize := estimatePageTable(virtualPages, pageSizeBytes, sizeOfPTE, levels)
+			fmt.Printf("Tamanho estimado da Tabela de Páginas (%d niveis): %d bytes\n", levels, multiLevelTableSize)
+
+			flatFullSize := flatAddressSpaceTableSize(pageSizeBytes, sizeOfPTE, levels)
+			fmt.Printf("Tamanho de uma tabela de 1 nivel cobrindo todo o espaco de enderecamento: %d bytes\n", flatFullSize)
+			if flatFullSize > 0 {
+				savings := 100.0 * (1.0 - float64(multiLevelTableSize)/float64(flatFullSize))
+				fmt.Printf("Economia de espaco em relacao a tabela de 1 nivel completa: %.2f%%\n", savings)
+			}
+		}
+	}
+
+	fmt.Printf("Com o algoritmo Ótimo ocorrem %d faltas de página.\n", optimalResult.PageFaults)
+	fmt.Printf("Com o algoritmo FIFO ocorrem %d faltas de página,\n", fifoResult.PageFaults)
+
+	efficiency := 100.0
+	if fifoResult.PageFaults > 0 {
+		efficiency = (float64(optimalResult.PageFaults) / float64(fifoResult.PageFaults)) * 100.0
+	}
+	fmt.Printf("atingindo %.2f%% do desempenho do Ótimo.\n", efficiency)
+
+	// Tabela comparativa entre todos os algoritmos
+	fmt.Println("\n--- TABELA COMPARATIVA ---")
+	fmt.Println("Algoritmo\tFaltas\t% do Ótimo")
+	fmt.Println("---------\t------\t----------")
+	for _, summary := range summaries {
+		pctOfOptimal := 100.0
+		if summary.result.PageFaults > 0 {
+			pctOfOptimal = (float64(optimalResult.PageFaults) / float64(summary.result.PageFaults)) * 100.0
+		}
+		fmt.Printf("%s\t%d\t%.2f%%\n", summary.name, summary.result.PageFaults, pctOfOptimal)
+	}
+
+	// Pergunta se deseja imprimir estatísticas por página
+	fmt.Print("Deseja listar o numero de carregamentos (s/n)? ")
+	var choice string
+	_, err = fmt.Scanln(&choice)
+	if err != nil && err != io.EOF {
+		choice = "n"
+	}
+
+	if strings.ToLower(choice) == "s" {
+		distinctPages := make([]string, 0, len(distinctPagesSet))
+		for page := range distinctPagesSet {
+			distinctPages = append(distinctPages, page)
+		}
+		sort.Strings(distinctPages)
+
+		fmt.Println("\nPágina\tÓtimo\tFIFO\tLRU\tLFU\tNUR")
+		fmt.Println("------\t-----\t----\t---\t---\t---")
+		for _, page := range distinctPages {
+			fmt.Printf("%s\t%d\t%d\t%d\t%d\t%d\n", page,
+				optimalResult.LoadCounts[page], fifoResult.LoadCounts[page],
+				lruResult.LoadCounts[page], lfuResult.LoadCounts[page], nurResult.LoadCounts[page])
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package pagerepl
+
+import "container/list"
+
+// FIFOAlgorithm implementa o algoritmo de substituição de páginas FIFO.
+//
+// Utiliza uma lista duplamente encadeada (`list.List`) para armazenar a ordem de chegada das
+// páginas e um mapa (`map[string]struct{}`) para checagem rápida de presença.
+type FIFOAlgorithm struct {
+	numFrames int
+	pageSet   map[string]struct{}
+	order     *list.List
+}
+
+// NewFIFOAlgorithm cria um FIFOAlgorithm pronto para ser usado com um Simulator.
+func NewFIFOAlgorithm() *FIFOAlgorithm {
+	return &FIFOAlgorithm{}
+}
+
+// Name devolve o nome do algoritmo.
+func (a *FIFOAlgorithm) Name() string {
+	return "FIFO"
+}
+
+// Reset descarta o estado anterior e prepara o algoritmo para numFrames quadros de memória.
+func (a *FIFOAlgorithm) Reset(numFrames int, hints Hints) {
+	a.numFrames = numFrames
+	a.pageSet = make(map[string]struct{})
+	a.order = list.New()
+}
+
+// Access processa o acesso à página, devolvendo se houve falta e qual página foi removida.
+func (a *FIFOAlgorithm) Access(page string, step int) (fault bool, evicted string) {
+	if _, found := a.pageSet[page]; found {
+		return false, ""
+	}
+
+	fault = true
+	if a.order.Len() == a.numFrames {
+		oldest := a.order.Front()
+		evicted = oldest.Value.(string)
+		delete(a.pageSet, evicted)
+		a.order.Remove(oldest)
+	}
+
+	a.order.PushBack(page)
+	a.pageSet[page] = struct{}{}
+	return fault, evicted
+}
+
+// Frames devolve as páginas em memória na ordem de chegada, da mais antiga para a mais nova.
+func (a *FIFOAlgorithm) Frames() []string {
+	frames := make([]string, 0, a.order.Len())
+	for e := a.order.Front(); e != nil; e = e.Next() {
+		frames = append(frames, e.Value.(string))
+	}
+	return frames
+}
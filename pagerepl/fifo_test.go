@@ -0,0 +1,25 @@
+package pagerepl
+
+import "testing"
+
+func TestFIFOAlgorithm_PageFaults(t *testing.T) {
+	cases := []struct {
+		name      string
+		refs      []string
+		numFrames int
+		wantFault int
+	}{
+		{"reuso de localidade em A e B", []string{"A", "B", "C", "A", "B", "D", "A", "B", "C", "D"}, 3, 8},
+		{"anomalia de Belady", []string{"1", "2", "3", "4", "1", "2", "5", "1", "2", "3", "4", "5"}, 3, 9},
+		{"trace classico de Belady", []string{"7", "0", "1", "2", "0", "3", "0", "4", "2", "3", "0", "3", "2"}, 3, 10},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := faultCount(NewFIFOAlgorithm(), tc.refs, tc.numFrames)
+			if got != tc.wantFault {
+				t.Errorf("faults = %d, want %d", got, tc.wantFault)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package pagerepl
+
+import "sort"
+
+// LFUAlgorithm implementa o algoritmo de substituição de páginas LFU (Least Frequently Used).
+//
+// Mantém um contador de frequência por página, incrementado a cada carregamento e a cada
+// acerto. Na remoção, escolhe o quadro com o menor contador, desempatando pela página
+// carregada há mais tempo.
+type LFUAlgorithm struct {
+	numFrames     int
+	memoryFrames  []string
+	pageSet       map[string]struct{}
+	frequency     map[string]int
+	insertionTime map[string]int
+}
+
+// NewLFUAlgorithm cria um LFUAlgorithm pronto para ser usado com um Simulator.
+func NewLFUAlgorithm() *LFUAlgorithm {
+	return &LFUAlgorithm{}
+}
+
+// Name devolve o nome do algoritmo.
+func (a *LFUAlgorithm) Name() string {
+	return "LFU"
+}
+
+// Reset descarta o estado anterior e prepara o algoritmo para numFrames quadros de memória.
+func (a *LFUAlgorithm) Reset(numFrames int, hints Hints) {
+	a.numFrames = numFrames
+	a.memoryFrames = make([]string, 0, numFrames)
+	a.pageSet = make(map[string]struct{})
+	a.frequency = make(map[string]int)
+	a.insertionTime = make(map[string]int)
+}
+
+// Access processa o acesso à página, devolvendo se houve falta e qual página foi removida.
+func (a *LFUAlgorithm) Access(page string, step int) (fault bool, evicted string) {
+	if _, found := a.pageSet[page]; found {
+		a.frequency[page]++
+		return false, ""
+	}
+
+	fault = true
+	a.frequency[page]++
+
+	if len(a.memoryFrames) < a.numFrames {
+		a.memoryFrames = append(a.memoryFrames, page)
+	} else {
+		victimIndex := 0
+		for frameIdx, framePage := range a.memoryFrames {
+			if a.frequency[framePage] < a.frequency[a.memoryFrames[victimIndex]] ||
+				(a.frequency[framePage] == a.frequency[a.memoryFrames[victimIndex]] &&
+					a.insertionTime[framePage] < a.insertionTime[a.memoryFrames[victimIndex]]) {
+				victimIndex = frameIdx
+			}
+		}
+		evicted = a.memoryFrames[victimIndex]
+		delete(a.pageSet, evicted)
+		a.memoryFrames[victimIndex] = page
+	}
+
+	a.pageSet[page] = struct{}{}
+	a.insertionTime[page] = step
+	return fault, evicted
+}
+
+// Frames devolve as páginas em memória, ordenadas para uma exibição estável.
+func (a *LFUAlgorithm) Frames() []string {
+	frames := make([]string, len(a.memoryFrames))
+	copy(frames, a.memoryFrames)
+	sort.Strings(frames)
+	return frames
+}
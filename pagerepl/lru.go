@@ -0,0 +1,59 @@
+package pagerepl
+
+import "container/list"
+
+// LRUAlgorithm implementa o algoritmo de substituição de páginas LRU (Least Recently Used).
+//
+// Utiliza uma lista duplamente encadeada (`list.List`) para manter a ordem de uso e um mapa
+// (`map[string]*list.Element`) para localizar e mover um elemento para o fim em O(1) a cada
+// acesso. O início da lista é sempre a página usada há mais tempo.
+type LRUAlgorithm struct {
+	numFrames    int
+	pageElements map[string]*list.Element
+	usageOrder   *list.List
+}
+
+// NewLRUAlgorithm cria um LRUAlgorithm pronto para ser usado com um Simulator.
+func NewLRUAlgorithm() *LRUAlgorithm {
+	return &LRUAlgorithm{}
+}
+
+// Name devolve o nome do algoritmo.
+func (a *LRUAlgorithm) Name() string {
+	return "LRU"
+}
+
+// Reset descarta o estado anterior e prepara o algoritmo para numFrames quadros de memória.
+func (a *LRUAlgorithm) Reset(numFrames int, hints Hints) {
+	a.numFrames = numFrames
+	a.pageElements = make(map[string]*list.Element)
+	a.usageOrder = list.New()
+}
+
+// Access processa o acesso à página, devolvendo se houve falta e qual página foi removida.
+func (a *LRUAlgorithm) Access(page string, step int) (fault bool, evicted string) {
+	if element, found := a.pageElements[page]; found {
+		a.usageOrder.MoveToBack(element)
+		return false, ""
+	}
+
+	fault = true
+	if a.usageOrder.Len() == a.numFrames {
+		leastRecent := a.usageOrder.Front()
+		evicted = leastRecent.Value.(string)
+		delete(a.pageElements, evicted)
+		a.usageOrder.Remove(leastRecent)
+	}
+
+	a.pageElements[page] = a.usageOrder.PushBack(page)
+	return fault, evicted
+}
+
+// Frames devolve as páginas em memória da menos usada recentemente para a mais usada.
+func (a *LRUAlgorithm) Frames() []string {
+	frames := make([]string, 0, a.usageOrder.Len())
+	for e := a.usageOrder.Front(); e != nil; e = e.Next() {
+		frames = append(frames, e.Value.(string))
+	}
+	return frames
+}
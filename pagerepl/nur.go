@@ -0,0 +1,112 @@
+package pagerepl
+
+import "sort"
+
+// NURAlgorithm implementa o algoritmo de substituição de páginas NUR (Not-Used-Recently),
+// também conhecido como algoritmo do relógio (clock) ou segunda chance.
+//
+// Cada quadro mantém um bit de referência e um bit de modificação. Um ponteiro circular
+// percorre os quadros na remoção, limpando bits de referência ligados, e escolhe o primeiro
+// quadro com bit de referência 0, preferindo dentro dessa classe os quadros com bit de
+// modificação 0 (mais baratos de substituir, pois não precisam ser regravados em disco).
+//
+// Como o simulador não distingue acessos de leitura e escrita na referência de página, todo
+// carregamento é tratado como não modificado; a lógica de classes permanece pronta para uma
+// fonte de referências que informe operações de escrita.
+type NURAlgorithm struct {
+	numFrames      int
+	frames         []string // "" significa quadro vazio
+	referenceBit   []bool
+	modifiedBit    []bool
+	pageFrameIndex map[string]int
+	framesInUse    int
+	clockHand      int
+}
+
+// NewNURAlgorithm cria um NURAlgorithm pronto para ser usado com um Simulator.
+func NewNURAlgorithm() *NURAlgorithm {
+	return &NURAlgorithm{}
+}
+
+// Name devolve o nome do algoritmo.
+func (a *NURAlgorithm) Name() string {
+	return "NUR"
+}
+
+// Reset descarta o estado anterior e prepara o algoritmo para numFrames quadros de memória.
+func (a *NURAlgorithm) Reset(numFrames int, hints Hints) {
+	a.numFrames = numFrames
+	a.frames = make([]string, numFrames)
+	a.referenceBit = make([]bool, numFrames)
+	a.modifiedBit = make([]bool, numFrames)
+	a.pageFrameIndex = make(map[string]int)
+	a.framesInUse = 0
+	a.clockHand = 0
+}
+
+// Access processa o acesso à página, devolvendo se houve falta e qual página foi removida.
+func (a *NURAlgorithm) Access(page string, step int) (fault bool, evicted string) {
+	if idx, found := a.pageFrameIndex[page]; found {
+		a.referenceBit[idx] = true
+		return false, ""
+	}
+
+	fault = true
+	var targetIdx int
+	if a.framesInUse < a.numFrames {
+		targetIdx = a.framesInUse
+		a.framesInUse++
+	} else {
+		targetIdx = nurSelectVictim(a.referenceBit, a.modifiedBit, a.clockHand)
+		evicted = a.frames[targetIdx]
+		delete(a.pageFrameIndex, evicted)
+		a.clockHand = (targetIdx + 1) % a.numFrames
+	}
+
+	a.frames[targetIdx] = page
+	a.referenceBit[targetIdx] = false
+	a.modifiedBit[targetIdx] = false
+	a.pageFrameIndex[page] = targetIdx
+	return fault, evicted
+}
+
+// Frames devolve as páginas em memória, ordenadas para uma exibição estável.
+func (a *NURAlgorithm) Frames() []string {
+	frames := make([]string, a.framesInUse)
+	copy(frames, a.frames[:a.framesInUse])
+	sort.Strings(frames)
+	return frames
+}
+
+// nurSelectVictim varre os quadros a partir de clockHand em até duas passadas, como no
+// algoritmo clássico da segunda chance aprimorada. A primeira passada procura, sem alterar
+// nenhum bit, um quadro com bit de referência 0 e modificado 0. Se não encontrar, a segunda
+// passada procura um quadro com bit de referência 0 (já aceitando modificado 1), limpando o
+// bit de referência de cada quadro referenciado que o ponteiro ultrapassa antes de parar —
+// nunca dos quadros após a parada. Se todos os bits de referência estiverem ligados, a
+// segunda passada os limpa por completo e o próprio clockHand é devolvido.
+//
+// :param referenceBit: Bits de referência de cada quadro
+// :param modifiedBit: Bits de modificação de cada quadro
+// :param clockHand: Posição inicial da varredura
+// :return: Índice do quadro escolhido para remoção
+func nurSelectVictim(referenceBit []bool, modifiedBit []bool, clockHand int) int {
+	numFrames := len(referenceBit)
+
+	for count := 0; count < numFrames; count++ {
+		idx := (clockHand + count) % numFrames
+		if !referenceBit[idx] && !modifiedBit[idx] {
+			return idx
+		}
+	}
+
+	for count := 0; count < numFrames; count++ {
+		idx := (clockHand + count) % numFrames
+		if !referenceBit[idx] {
+			return idx
+		}
+		referenceBit[idx] = false
+	}
+
+	return clockHand
+}
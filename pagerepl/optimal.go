@@ -0,0 +1,88 @@
+package pagerepl
+
+import "sort"
+
+// OptimalAlgorithm implementa o algoritmo ótimo de substituição de páginas.
+//
+// Utiliza as posições futuras de uso, vindas de Hints.PagePositions, para decidir qual página
+// remover: sempre aquela que será usada mais tarde ou nunca mais usada.
+type OptimalAlgorithm struct {
+	numFrames     int
+	pagePositions map[string][]int
+	nextUseCursor map[string]int
+	memoryFrames  []string
+	pageSet       map[string]struct{}
+}
+
+// NewOptimalAlgorithm cria um OptimalAlgorithm pronto para ser usado com um Simulator.
+func NewOptimalAlgorithm() *OptimalAlgorithm {
+	return &OptimalAlgorithm{}
+}
+
+// Name devolve o nome do algoritmo.
+func (a *OptimalAlgorithm) Name() string {
+	return "Ótimo"
+}
+
+// Reset descarta o estado anterior e prepara o algoritmo, guardando hints.PagePositions.
+func (a *OptimalAlgorithm) Reset(numFrames int, hints Hints) {
+	a.numFrames = numFrames
+	a.pagePositions = hints.PagePositions
+	a.nextUseCursor = make(map[string]int)
+	a.memoryFrames = make([]string, 0, numFrames)
+	a.pageSet = make(map[string]struct{})
+}
+
+// Access processa o acesso à página, devolvendo se houve falta e qual página foi removida.
+func (a *OptimalAlgorithm) Access(page string, step int) (fault bool, evicted string) {
+	if _, found := a.pageSet[page]; found {
+		return false, ""
+	}
+
+	fault = true
+	if len(a.memoryFrames) < a.numFrames {
+		a.memoryFrames = append(a.memoryFrames, page)
+		a.pageSet[page] = struct{}{}
+		return fault, ""
+	}
+
+	farthest := -1
+	victimIndex := -1
+
+	for frameIdx, framePage := range a.memoryFrames {
+		positions := a.pagePositions[framePage]
+		cursor := a.nextUseCursor[framePage]
+
+		nextPos := -1
+		for cursor < len(positions) && positions[cursor] <= step {
+			cursor++
+		}
+		a.nextUseCursor[framePage] = cursor
+		if cursor < len(positions) {
+			nextPos = positions[cursor]
+		}
+
+		if nextPos == -1 {
+			victimIndex = frameIdx
+			break
+		}
+		if nextPos > farthest {
+			farthest = nextPos
+			victimIndex = frameIdx
+		}
+	}
+
+	evicted = a.memoryFrames[victimIndex]
+	delete(a.pageSet, evicted)
+	a.memoryFrames[victimIndex] = page
+	a.pageSet[page] = struct{}{}
+	return fault, evicted
+}
+
+// Frames devolve as páginas em memória, ordenadas para uma exibição estável.
+func (a *OptimalAlgorithm) Frames() []string {
+	frames := make([]string, len(a.memoryFrames))
+	copy(frames, a.memoryFrames)
+	sort.Strings(frames)
+	return frames
+}
@@ -0,0 +1,57 @@
+// Package pagerepl implementa algoritmos de substituição de páginas e um motor de simulação
+// que os conduz sobre uma sequência de referências de página, emitindo eventos estruturados
+// que tanto uma CLI quanto outros consumidores (uma interface web, um benchmark) podem consumir.
+package pagerepl
+
+// SimulationResult agrupa os resultados de uma simulação de substituição de páginas.
+type SimulationResult struct {
+	PageFaults int            // Total de faltas de página
+	LoadCounts map[string]int // Quantas vezes cada página foi carregada na memória
+}
+
+// Hints carrega informação auxiliar pré-computada que alguns algoritmos precisam para decidir
+// qual página remover. Hoje só o algoritmo Ótimo usa PagePositions, mas o tipo fica aberto para
+// futuros algoritmos que precisem de outro tipo de dica.
+type Hints struct {
+	PagePositions map[string][]int // Posições futuras de cada página na sequência de referências
+}
+
+// BuildPagePositions pré-computa, para cada página distinta, a lista ordenada de posições em
+// que ela aparece na sequência de referências. É o hint consumido pelo algoritmo Ótimo.
+//
+// :param pageReferences: Sequência de referências de página
+// :return: Mapa de página para suas posições na sequência
+func BuildPagePositions(pageReferences []string) map[string][]int {
+	pagePositions := make(map[string][]int)
+	for i, page := range pageReferences {
+		pagePositions[page] = append(pagePositions[page], i)
+	}
+	return pagePositions
+}
+
+// Algorithm é a interface que todo algoritmo de substituição de páginas implementa, permitindo
+// que o Simulator conduza qualquer combinação deles sobre a mesma sequência de referências.
+type Algorithm interface {
+	// Name identifica o algoritmo nas tabelas de resultado e nos eventos emitidos.
+	Name() string
+	// Reset descarta qualquer estado anterior e prepara o algoritmo para uma nova simulação
+	// com numFrames quadros de memória disponíveis.
+	Reset(numFrames int, hints Hints)
+	// Access processa o acesso à página no passo indicado, devolvendo se houve falta de página
+	// e, em caso de falta com substituição, qual página foi removida ("" se nenhuma).
+	Access(page string, step int) (fault bool, evicted string)
+	// Frames devolve o conteúdo atual da memória, na ordem que o algoritmo considerar natural
+	// para exibição (ordem de uso para os algoritmos baseados em lista, ordenada para os demais).
+	Frames() []string
+}
+
+// Event descreve o resultado de um único acesso de um único algoritmo, pronto para ser
+// impresso pela CLI em modo didático ou consumido por qualquer outro observador.
+type Event struct {
+	Step      int      // Número do passo (1-based), igual para todos os algoritmos naquele acesso
+	Algorithm string   // Nome do algoritmo que processou o acesso
+	Page      string   // Página acessada
+	Fault     bool     // Se houve falta de página
+	Evicted   string   // Página removida da memória, "" se nenhuma
+	Frames    []string // Conteúdo da memória após o acesso
+}
@@ -0,0 +1,16 @@
+package pagerepl
+
+// faultCount conduz algo sobre refs com numFrames quadros, usando BuildPagePositions como hint
+// (único algoritmo que o consome é o Ótimo), e devolve o total de faltas de página.
+func faultCount(algo Algorithm, refs []string, numFrames int) int {
+	hints := Hints{PagePositions: BuildPagePositions(refs)}
+	algo.Reset(numFrames, hints)
+
+	faults := 0
+	for step, page := range refs {
+		if fault, _ := algo.Access(page, step); fault {
+			faults++
+		}
+	}
+	return faults
+}
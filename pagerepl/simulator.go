@@ -0,0 +1,88 @@
+package pagerepl
+
+// Simulator conduz um conjunto de algoritmos de substituição de páginas sobre a mesma
+// sequência de referências, quadro a quadro, emitindo um Event por acesso de cada algoritmo.
+type Simulator struct {
+	NumFrames  int
+	Algorithms []Algorithm
+}
+
+// NewSimulator cria um Simulator para o número de quadros e os algoritmos informados.
+//
+// :param numFrames: Número de quadros de memória disponíveis
+// :param algorithms: Algoritmos a executar, na ordem em que aparecerão nos eventos
+// :return: Simulator pronto para rodar
+func NewSimulator(numFrames int, algorithms ...Algorithm) *Simulator {
+	return &Simulator{NumFrames: numFrames, Algorithms: algorithms}
+}
+
+// Run lê a sequência de referências da fonte informada e conduz todos os algoritmos sobre ela,
+// um acesso por vez. Os eventos de cada acesso são emitidos no canal devolvido assim que
+// ocorrem; o resultado final de cada algoritmo, indexado pelo seu Name(), chega no segundo
+// canal quando a simulação termina. O consumidor deve drenar o canal de eventos (mesmo que
+// apenas para descartá-los) para que a simulação progrida.
+//
+// emitFrames controla se Event.Frames é preenchido. Chamar Frames() em cada algoritmo faz uma
+// cópia e, em alguns algoritmos, uma ordenação a cada acesso; em modo não-didático, onde
+// ninguém olha para esse campo, isso é desperdício puro nos traces grandes que esta simulação
+// existe para processar. Passe true só quando algum consumidor (como a exibição didática) de
+// fato usa o conteúdo da memória.
+//
+// :param source: Fonte da sequência de referências de página
+// :param hints: Dicas pré-computadas repassadas a Reset de cada algoritmo
+// :param emitFrames: Se true, Event.Frames é preenchido; caso contrário, vem nil
+// :return: Canal de eventos, canal do resultado final por algoritmo, e um erro se a fonte falhar
+func (s *Simulator) Run(source PageSource, hints Hints, emitFrames bool) (<-chan Event, <-chan map[string]SimulationResult, error) {
+	pages, err := source.Pages()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, algorithm := range s.Algorithms {
+		algorithm.Reset(s.NumFrames, hints)
+	}
+
+	events := make(chan Event, 64)
+	results := make(chan map[string]SimulationResult, 1)
+
+	go func() {
+		defer close(events)
+		defer close(results)
+
+		resultByName := make(map[string]SimulationResult, len(s.Algorithms))
+		for _, algorithm := range s.Algorithms {
+			resultByName[algorithm.Name()] = SimulationResult{LoadCounts: make(map[string]int)}
+		}
+
+		for step, page := range pages {
+			for _, algorithm := range s.Algorithms {
+				fault, evicted := algorithm.Access(page, step)
+
+				var frames []string
+				if emitFrames {
+					frames = algorithm.Frames()
+				}
+
+				events <- Event{
+					Step:      step + 1,
+					Algorithm: algorithm.Name(),
+					Page:      page,
+					Fault:     fault,
+					Evicted:   evicted,
+					Frames:    frames,
+				}
+
+				if fault {
+					result := resultByName[algorithm.Name()]
+					result.PageFaults++
+					result.LoadCounts[page]++
+					resultByName[algorithm.Name()] = result
+				}
+			}
+		}
+
+		results <- resultByName
+	}()
+
+	return events, results, nil
+}
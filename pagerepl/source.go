@@ -0,0 +1,57 @@
+package pagerepl
+
+import (
+	"bufio"
+	"io"
+	"os"
+)
+
+// PageSource fornece a sequência de referências de página que o Simulator deve percorrer.
+// Implementações típicas lêem de um arquivo, de um gerador sintético já materializado em
+// memória, ou de qualquer io.Reader.
+type PageSource interface {
+	Pages() ([]string, error)
+}
+
+// FilePageSource lê referências de página, uma por linha, de um arquivo em disco.
+type FilePageSource struct {
+	Path string
+}
+
+// Pages abre o arquivo e devolve uma referência de página por linha.
+func (s FilePageSource) Pages() ([]string, error) {
+	file, err := os.Open(s.Path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return ReaderPageSource{R: file}.Pages()
+}
+
+// ReaderPageSource lê referências de página, uma por linha, de um io.Reader qualquer.
+type ReaderPageSource struct {
+	R io.Reader
+}
+
+// Pages lê todas as linhas do reader, cada uma como uma referência de página.
+func (s ReaderPageSource) Pages() ([]string, error) {
+	pages := make([]string, 0)
+	scanner := bufio.NewScanner(s.R)
+	for scanner.Scan() {
+		pages = append(pages, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return pages, nil
+}
+
+// SlicePageSource expõe uma sequência de referências de página já em memória, por exemplo a
+// saída de um gerador sintético.
+type SlicePageSource []string
+
+// Pages devolve a própria sequência, sem nenhuma cópia ou leitura adicional.
+func (s SlicePageSource) Pages() ([]string, error) {
+	return []string(s), nil
+}